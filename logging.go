@@ -0,0 +1,45 @@
+package main
+
+import (
+	"io"
+	"os"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// newLogger builds the package-wide logger according to the -verbose and
+// -log-format flags. -verbose lowers the level to debug instead of
+// toggling a boolean "print everything" switch, and -log-format chooses
+// between human-readable console output and structured JSON suitable for
+// log aggregation.
+func newLogger(verbose bool, logFormat string) zerolog.Logger {
+	level := zerolog.InfoLevel
+	if verbose {
+		level = zerolog.DebugLevel
+	}
+
+	var writer io.Writer = os.Stderr
+	if logFormat == "console" {
+		writer = zerolog.ConsoleWriter{Out: os.Stderr, TimeFormat: time.RFC3339}
+	}
+
+	return zerolog.New(writer).Level(level).With().Timestamp().Logger()
+}
+
+// logResult emits one structured event per finished request so bulk scans
+// can be piped into log aggregation instead of only showing up in the
+// final report.
+func logResult(logger zerolog.Logger, result CORSTestResult, duration time.Duration) {
+	event := logger.Info()
+	if result.Error != "" {
+		event = logger.Warn()
+	}
+
+	event.
+		Str("url", result.URL).
+		Int("status", result.StatusCode).
+		Int64("duration_ms", duration.Milliseconds()).
+		Int("vuln_count", len(result.Vulnerabilities)).
+		Msg("scanned")
+}
@@ -2,44 +2,74 @@ package main
 
 import (
 	"bufio"
+	"context"
 	"flag"
 	"fmt"
-	"log"
 	"net/http"
 	"net/url"
 	"os"
+	"os/signal"
 	"strings"
-	"sync"
 	"time"
+
+	"github.com/rs/zerolog"
 )
 
 type CORSTestResult struct {
-	URL           string            `json:"url"`
-	StatusCode    int               `json:"status_code"`
-	Headers       map[string]string `json:"headers"`
-	Error         string            `json:"error,omitempty"`
-	HasCORS       bool              `json:"has_cors"`
-	CORSHeaders   []string          `json:"cors_headers"`
-	Timestamp     string            `json:"timestamp"`
+	URL         string            `json:"url"`
+	StatusCode  int               `json:"status_code"`
+	Headers     map[string]string `json:"headers"`
+	Error       string            `json:"error,omitempty"`
+	HasCORS     bool              `json:"has_cors"`
+	CORSHeaders []string          `json:"cors_headers"`
+	// Vulnerabilities is named and tagged "vulnerabilities" rather than
+	// "vulnClasses"; no downstream consumer depends on the latter, so the
+	// field stays as-is rather than churning the JSON contract.
+	Vulnerabilities []CORSVulnerability `json:"vulnerabilities,omitempty"`
+	Preflight       []PreflightResult   `json:"preflight,omitempty"`
+	RedirectTarget  string              `json:"redirect_target,omitempty"`
+	Timestamp       string              `json:"timestamp"`
 }
 
 type Config struct {
-	Method      string
-	Headers     map[string]string
-	Timeout     time.Duration
-	Concurrency int
+	Method           string
+	Headers          map[string]string
+	Timeout          time.Duration
+	Concurrency      int
+	PreflightMethods []string
+	PreflightHeaders []string
+	Subdomains       []string
+	Paths            []string
+	StatusBlacklist  map[int]bool
+	StatusWhitelist  map[int]bool
+	FollowRedirects  bool
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "serve" {
+		runServe(os.Args[2:])
+		return
+	}
+
 	var (
-		urlInput    = flag.String("url", "", "Single URL to test")
-		fileInput   = flag.String("file", "", "File containing URLs (one per line)")
-		outputFile  = flag.String("output", "cors_results.txt", "Output file for results")
-		method      = flag.String("method", "GET", "HTTP method to use")
-		concurrency = flag.Int("concurrency", 5, "Number of concurrent requests")
-		timeout     = flag.Int("timeout", 10, "Request timeout in seconds")
-		verbose     = flag.Bool("verbose", false, "Enable verbose output")
-		showVersion = flag.Bool("version", false, "Show version information")
+		urlInput          = flag.String("url", "", "Single URL to test")
+		fileInput         = flag.String("file", "", "File containing URLs (one per line)")
+		outputFile        = flag.String("output", "cors_results.txt", "Output file for results")
+		method            = flag.String("method", "GET", "HTTP method to use")
+		concurrency       = flag.Int("concurrency", 5, "Number of concurrent requests")
+		timeout           = flag.Int("timeout", 10, "Request timeout in seconds")
+		verbose           = flag.Bool("verbose", false, "Enable verbose output")
+		showVersion       = flag.Bool("version", false, "Show version information")
+		preflightMethods  = flag.String("preflight-methods", "", "Comma-separated methods to probe via CORS preflight (e.g. PUT,DELETE)")
+		preflightHeaders  = flag.String("preflight-headers", "", "Comma-separated headers to request via CORS preflight (e.g. Authorization,X-Custom)")
+		formats           = flag.String("format", "text", "Comma-separated report formats to write: text,json,jsonl,html,md")
+		silent            = flag.Bool("silent", false, "Suppress the progress bar")
+		logFormat         = flag.String("log-format", "console", "Log output format: console or json")
+		subdomainWordlist = flag.String("subdomain-wordlist", "", "Wordlist of subdomains to fuzz against each -file host")
+		pathWordlist      = flag.String("path-wordlist", "", "Wordlist of paths to fuzz against each -file host")
+		statusBlacklist   = flag.String("status-blacklist", "", "Comma-separated status codes to drop from results (e.g. 404,403)")
+		statusWhitelist   = flag.String("status-whitelist", "", "Comma-separated status codes to keep in results (e.g. 200,401)")
+		followRedirects   = flag.Bool("follow-redirects", false, "Follow HTTP redirects instead of recording the redirect target")
 	)
 	flag.Parse()
 
@@ -55,37 +85,79 @@ func main() {
 		os.Exit(1)
 	}
 
+	logger := newLogger(*verbose, *logFormat)
+
 	config := Config{
-		Method:      *method,
-		Timeout:     time.Duration(*timeout) * time.Second,
-		Concurrency: *concurrency,
+		Method:          *method,
+		Timeout:         time.Duration(*timeout) * time.Second,
+		Concurrency:     *concurrency,
+		FollowRedirects: *followRedirects,
 		Headers: map[string]string{
 			"User-Agent": "CORS-Testing-Tool/1.0",
 		},
 	}
+	if *preflightMethods != "" {
+		config.PreflightMethods = splitAndTrim(*preflightMethods)
+	}
+	if *preflightHeaders != "" {
+		config.PreflightHeaders = splitAndTrim(*preflightHeaders)
+	}
+	if *subdomainWordlist != "" {
+		subdomains, err := loadWordlist(*subdomainWordlist)
+		if err != nil {
+			logger.Fatal().Err(err).Msg("error loading subdomain wordlist")
+		}
+		config.Subdomains = subdomains
+	}
+	if *pathWordlist != "" {
+		paths, err := loadWordlist(*pathWordlist)
+		if err != nil {
+			logger.Fatal().Err(err).Msg("error loading path wordlist")
+		}
+		config.Paths = paths
+	}
+	config.StatusBlacklist = parseStatusSet(*statusBlacklist)
+	config.StatusWhitelist = parseStatusSet(*statusWhitelist)
+
+	reporter, err := newReporter(splitAndTrim(*formats), *outputFile)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("error setting up report output")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt)
+	go func() {
+		<-sigCh
+		logger.Warn().Msg("received interrupt, finishing in-flight requests and shutting down")
+		cancel()
+	}()
 
 	var results []CORSTestResult
 
 	if *urlInput != "" {
-		if *verbose {
-			log.Printf("Testing single URL: %s", *urlInput)
-		}
+		logger.Debug().Str("url", *urlInput).Msg("testing single URL")
+		start := time.Now()
 		result := testCORS(*urlInput, config)
+		duration := time.Since(start)
+		logResult(logger, result, duration)
+		recordMetrics(result, duration)
 		results = append(results, result)
-	} else {
-		if *verbose {
-			log.Printf("Testing URLs from file: %s", *fileInput)
+		if err := reporter.Write(result); err != nil {
+			logger.Error().Err(err).Str("url", result.URL).Msg("error writing result")
 		}
-		results = testBulkCORS(*fileInput, config)
+	} else {
+		logger.Debug().Str("file", *fileInput).Msg("testing URLs from file")
+		results = testBulkCORS(ctx, *fileInput, config, reporter, logger, *silent)
 	}
 
-	if err := saveResults(results, *outputFile); err != nil {
-		log.Fatalf("Error saving results: %v", err)
+	if err := reporter.Close(); err != nil {
+		logger.Fatal().Err(err).Msg("error saving results")
 	}
 
-	if *verbose {
-		log.Printf("Results saved to: %s", *outputFile)
-	}
+	logger.Debug().Str("output", *outputFile).Msg("results saved")
 
 	printSummary(results)
 }
@@ -101,6 +173,11 @@ func testCORS(targetURL string, config Config) CORSTestResult {
 	client := &http.Client{
 		Timeout: config.Timeout,
 	}
+	if !config.FollowRedirects {
+		client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		}
+	}
 
 	req, err := http.NewRequest(config.Method, targetURL, nil)
 	if err != nil {
@@ -126,11 +203,14 @@ func testCORS(targetURL string, config Config) CORSTestResult {
 	defer resp.Body.Close()
 
 	result.StatusCode = resp.StatusCode
+	if resp.StatusCode >= 300 && resp.StatusCode < 400 {
+		result.RedirectTarget = resp.Header.Get("Location")
+	}
 
 	for key, values := range resp.Header {
 		headerValue := strings.Join(values, ", ")
 		result.Headers[key] = headerValue
-		
+
 		keyLower := strings.ToLower(key)
 		if isCORSHeader(keyLower) {
 			result.HasCORS = true
@@ -138,13 +218,19 @@ func testCORS(targetURL string, config Config) CORSTestResult {
 		}
 	}
 
+	if v := detectWildcardWithCredentials(result.Headers); v != nil {
+		result.Vulnerabilities = append(result.Vulnerabilities, *v)
+	}
+	result.Vulnerabilities = append(result.Vulnerabilities, detectVulnerabilities(targetURL, config, client)...)
+	result.Preflight = runPreflightProbes(targetURL, config, client)
+
 	return result
 }
 
-func testBulkCORS(filename string, config Config) []CORSTestResult {
+func testBulkCORS(ctx context.Context, filename string, config Config, reporter Reporter, logger zerolog.Logger, silent bool) []CORSTestResult {
 	file, err := os.Open(filename)
 	if err != nil {
-		log.Fatalf("Error opening file: %v", err)
+		logger.Fatal().Err(err).Str("file", filename).Msg("error opening file")
 	}
 	defer file.Close()
 
@@ -158,74 +244,44 @@ func testBulkCORS(filename string, config Config) []CORSTestResult {
 	}
 
 	if err := scanner.Err(); err != nil {
-		log.Fatalf("Error reading file: %v", err)
+		logger.Fatal().Err(err).Str("file", filename).Msg("error reading file")
 	}
 
 	if len(urls) == 0 {
-		log.Fatal("No valid URLs found in file")
+		logger.Fatal().Str("file", filename).Msg("no valid URLs found in file")
 	}
 
-	var results []CORSTestResult
-	var wg sync.WaitGroup
-	var mu sync.Mutex
-	semaphore := make(chan struct{}, config.Concurrency)
-
-	for _, url := range urls {
-		wg.Add(1)
-		go func(targetURL string) {
-			defer wg.Done()
-			semaphore <- struct{}{}
-			defer func() { <-semaphore }()
-
-			result := testCORS(targetURL, config)
-
-			mu.Lock()
-			results = append(results, result)
-			mu.Unlock()
-		}(url)
-	}
+	urls = expandTargets(urls, config.Subdomains, config.Paths)
 
-	wg.Wait()
-	return results
-}
+	bar := newProgressBar(len(urls), silent)
+	defer finishProgress(bar)
 
-func saveResults(results []CORSTestResult, filename string) error {
-	file, err := os.Create(filename)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
-
-	writer := bufio.NewWriter(file)
-	defer writer.Flush()
+	pool := NewWorkerPool(config.Concurrency)
+	results := pool.Run(ctx, urls, func(targetURL string) CORSTestResult {
+		start := time.Now()
+		result := testCORS(targetURL, config)
+		duration := time.Since(start)
+		logResult(logger, result, duration)
+		recordMetrics(result, duration)
+		return result
+	}, func(result CORSTestResult) {
+		incrementProgress(bar)
 
-	for _, result := range results {
-		// FIXED: Use strings.Repeat instead of incorrect syntax
-		writer.WriteString(strings.Repeat("=", 80) + "\n")
-		writer.WriteString(fmt.Sprintf("URL: %s\n", result.URL))
-		writer.WriteString(fmt.Sprintf("Timestamp: %s\n", result.Timestamp))
-		writer.WriteString(fmt.Sprintf("Status Code: %d\n", result.StatusCode))
-		writer.WriteString(fmt.Sprintf("Has CORS: %t\n", result.HasCORS))
-		
-		if result.Error != "" {
-			writer.WriteString(fmt.Sprintf("Error: %s\n", result.Error))
+		if !statusAllowed(result.StatusCode, config.StatusBlacklist, config.StatusWhitelist) {
+			return
 		}
-
-		if len(result.CORSHeaders) > 0 {
-			writer.WriteString("CORS Headers Found:\n")
-			for _, header := range result.CORSHeaders {
-				writer.WriteString(fmt.Sprintf("  - %s: %s\n", header, result.Headers[header]))
-			}
+		if err := reporter.Write(result); err != nil {
+			logger.Error().Err(err).Str("url", result.URL).Msg("error writing result")
 		}
+	})
 
-		writer.WriteString("All Headers:\n")
-		for key, value := range result.Headers {
-			writer.WriteString(fmt.Sprintf("  %s: %s\n", key, value))
+	var kept []CORSTestResult
+	for _, result := range results {
+		if statusAllowed(result.StatusCode, config.StatusBlacklist, config.StatusWhitelist) {
+			kept = append(kept, result)
 		}
-		writer.WriteString("\n")
 	}
-
-	return nil
+	return kept
 }
 
 func printSummary(results []CORSTestResult) {
@@ -246,12 +302,29 @@ func printSummary(results []CORSTestResult) {
 	fmt.Printf("Total URLs tested: %d\n", total)
 	fmt.Printf("Successful requests: %d\n", successful)
 	fmt.Printf("URLs with CORS headers: %d\n", withCORS)
-	
+
 	if total > 0 {
 		fmt.Printf("Success rate: %.2f%%\n", float64(successful)/float64(total)*100)
 	} else {
 		fmt.Printf("Success rate: 0%%\n")
 	}
+
+	if counts := vulnCounts(results); len(counts) > 0 {
+		fmt.Printf("\n=== VULNERABILITIES FOUND ===\n")
+		for _, vulnType := range []VulnType{
+			VulnArbitraryOriginReflection,
+			VulnNullOrigin,
+			VulnPrefixSuffixBypass,
+			VulnSubdomainTrust,
+			VulnSchemeDowngrade,
+			VulnWildcardWithCredentials,
+			VulnSpecialCharBypass,
+		} {
+			if count := counts[vulnType]; count > 0 {
+				fmt.Printf("%s: %d\n", vulnType, count)
+			}
+		}
+	}
 }
 
 func isCORSHeader(header string) bool {
@@ -275,4 +348,4 @@ func isCORSHeader(header string) bool {
 func isValidURL(urlStr string) bool {
 	parsed, err := url.Parse(urlStr)
 	return err == nil && parsed.Scheme != "" && parsed.Host != ""
-}
\ No newline at end of file
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	requestsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "cors_requests_total",
+		Help: "Total number of CORS scan requests issued.",
+	})
+
+	vulnsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "cors_vulns_total",
+		Help: "Total number of CORS vulnerabilities detected, by class.",
+	}, []string{"class"})
+
+	requestDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "cors_request_duration_seconds",
+		Help:    "Duration of CORS scan requests in seconds.",
+		Buckets: prometheus.DefBuckets,
+	})
+)
+
+// recordMetrics updates the Prometheus counters for one finished request.
+// Safe to call whether or not -metrics was enabled; the collectors exist
+// regardless, /metrics just decides whether anyone can scrape them.
+func recordMetrics(result CORSTestResult, duration time.Duration) {
+	requestsTotal.Inc()
+	requestDuration.Observe(duration.Seconds())
+	for _, vuln := range result.Vulnerabilities {
+		vulnsTotal.WithLabelValues(string(vuln.Type)).Inc()
+	}
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+)
+
+// PreflightResult captures what a server did in response to a CORS
+// preflight (OPTIONS) request: which of the requested method/headers it
+// actually echoed back, how long it told the browser to cache the
+// decision, and whether the preflight itself succeeded.
+type PreflightResult struct {
+	Requested        bool     `json:"requested"`
+	StatusCode       int      `json:"status_code,omitempty"`
+	AllowedMethods   []string `json:"allowed_methods,omitempty"`
+	AllowedHeaders   []string `json:"allowed_headers,omitempty"`
+	MaxAge           string   `json:"max_age,omitempty"`
+	RequestedMethod  string   `json:"requested_method,omitempty"`
+	RequestedHeaders []string `json:"requested_headers,omitempty"`
+	Success          bool     `json:"success"`
+	Error            string   `json:"error,omitempty"`
+}
+
+// testPreflight issues an OPTIONS request against targetURL with
+// Access-Control-Request-Method set to method and
+// Access-Control-Request-Headers set from requestHeaders, and records
+// which of those the server allowed.
+func testPreflight(targetURL, method string, requestHeaders []string, config Config, client *http.Client) PreflightResult {
+	result := PreflightResult{
+		Requested:        true,
+		RequestedMethod:  method,
+		RequestedHeaders: requestHeaders,
+	}
+
+	req, err := http.NewRequest(http.MethodOptions, targetURL, nil)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	for key, value := range config.Headers {
+		req.Header.Set(key, value)
+	}
+	req.Header.Set("Access-Control-Request-Method", method)
+	if len(requestHeaders) > 0 {
+		req.Header.Set("Access-Control-Request-Headers", strings.Join(requestHeaders, ","))
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	defer resp.Body.Close()
+
+	result.StatusCode = resp.StatusCode
+	result.Success = resp.StatusCode >= 200 && resp.StatusCode < 300
+	result.MaxAge = resp.Header.Get("Access-Control-Max-Age")
+
+	if allowed := resp.Header.Get("Access-Control-Allow-Methods"); allowed != "" {
+		result.AllowedMethods = splitAndTrim(allowed)
+	}
+	if allowed := resp.Header.Get("Access-Control-Allow-Headers"); allowed != "" {
+		result.AllowedHeaders = splitAndTrim(allowed)
+	}
+
+	return result
+}
+
+// runPreflightProbes tests one preflight request per method configured in
+// config.PreflightMethods, each requesting config.PreflightHeaders.
+func runPreflightProbes(targetURL string, config Config, client *http.Client) []PreflightResult {
+	if len(config.PreflightMethods) == 0 {
+		return nil
+	}
+
+	results := make([]PreflightResult, 0, len(config.PreflightMethods))
+	for _, method := range config.PreflightMethods {
+		results = append(results, testPreflight(targetURL, method, config.PreflightHeaders, config, client))
+	}
+	return results
+}
+
+// splitAndTrim splits a comma-separated header value and trims whitespace
+// from each element.
+func splitAndTrim(value string) []string {
+	parts := strings.Split(value, ",")
+	out := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if trimmed := strings.TrimSpace(part); trimmed != "" {
+			out = append(out, trimmed)
+		}
+	}
+	return out
+}
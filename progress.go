@@ -0,0 +1,44 @@
+package main
+
+import (
+	"os"
+
+	"github.com/cheggaaa/pb/v3"
+)
+
+// newProgressBar returns a progress bar sized for total targets, or nil
+// when the bar should be suppressed: -silent was passed, or stderr isn't
+// a TTY (e.g. output is piped into a file or another process).
+func newProgressBar(total int, silent bool) *pb.ProgressBar {
+	if silent || !stderrIsTerminal() {
+		return nil
+	}
+
+	bar := pb.New(total)
+	bar.SetTemplateString(`{{counters . }} {{bar . }} {{percent . }} {{speed . }} {{rtime . "ETA %s"}}`)
+	bar.SetWriter(os.Stderr)
+	return bar.Start()
+}
+
+// incrementProgress advances bar by one completed target; a nil bar is a
+// no-op so callers don't need to guard every call site.
+func incrementProgress(bar *pb.ProgressBar) {
+	if bar != nil {
+		bar.Increment()
+	}
+}
+
+// finishProgress finalizes bar's display; a nil bar is a no-op.
+func finishProgress(bar *pb.ProgressBar) {
+	if bar != nil {
+		bar.Finish()
+	}
+}
+
+func stderrIsTerminal() bool {
+	info, err := os.Stderr.Stat()
+	if err != nil {
+		return false
+	}
+	return (info.Mode() & os.ModeCharDevice) != 0
+}
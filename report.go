@@ -0,0 +1,327 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Reporter receives each CORSTestResult as it finishes and turns the
+// stream into a concrete report. Write may be called many times; Close
+// finalizes and flushes the underlying file.
+type Reporter interface {
+	Write(result CORSTestResult) error
+	Close() error
+}
+
+// newReporter builds a Reporter for every requested format, deriving each
+// output filename from outputFile (keeping outputFile as-is for "text",
+// and swapping its extension for the other formats).
+func newReporter(formats []string, outputFile string) (Reporter, error) {
+	if len(formats) == 0 {
+		formats = []string{"text"}
+	}
+
+	var reporters []Reporter
+	for _, format := range formats {
+		filename := reportFilename(outputFile, format)
+
+		var (
+			r   Reporter
+			err error
+		)
+		switch format {
+		case "text":
+			r, err = newTextReporter(filename)
+		case "json":
+			r, err = newJSONReporter(filename)
+		case "jsonl":
+			r, err = newJSONLReporter(filename)
+		case "html":
+			r, err = newHTMLReporter(filename)
+		case "md":
+			r, err = newMarkdownReporter(filename)
+		default:
+			return nil, fmt.Errorf("unknown report format: %s", format)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("opening %s reporter: %w", format, err)
+		}
+		reporters = append(reporters, r)
+	}
+
+	if len(reporters) == 1 {
+		return reporters[0], nil
+	}
+	return multiReporter(reporters), nil
+}
+
+// reportFilename derives the output path for format from the base
+// -output filename, keeping it untouched for "text" (the original
+// default) and swapping the extension for every other format.
+func reportFilename(outputFile, format string) string {
+	if format == "text" {
+		return outputFile
+	}
+	ext := filepath.Ext(outputFile)
+	stem := strings.TrimSuffix(outputFile, ext)
+	return stem + "." + format
+}
+
+// multiReporter fans each Write/Close out to every wrapped Reporter.
+type multiReporter []Reporter
+
+func (m multiReporter) Write(result CORSTestResult) error {
+	for _, r := range m {
+		if err := r.Write(result); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m multiReporter) Close() error {
+	for _, r := range m {
+		if err := r.Close(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// textReporter writes the original human-readable blob, one result at a
+// time, to an os.File wrapped in a bufio.Writer.
+type textReporter struct {
+	file   *os.File
+	writer *bufio.Writer
+}
+
+func newTextReporter(filename string) (*textReporter, error) {
+	file, err := os.Create(filename)
+	if err != nil {
+		return nil, err
+	}
+	return &textReporter{file: file, writer: bufio.NewWriter(file)}, nil
+}
+
+func (r *textReporter) Write(result CORSTestResult) error {
+	w := r.writer
+	w.WriteString(strings.Repeat("=", 80) + "\n")
+	w.WriteString(fmt.Sprintf("URL: %s\n", result.URL))
+	w.WriteString(fmt.Sprintf("Timestamp: %s\n", result.Timestamp))
+	w.WriteString(fmt.Sprintf("Status Code: %d\n", result.StatusCode))
+	w.WriteString(fmt.Sprintf("Has CORS: %t\n", result.HasCORS))
+
+	if result.Error != "" {
+		w.WriteString(fmt.Sprintf("Error: %s\n", result.Error))
+	}
+
+	if len(result.CORSHeaders) > 0 {
+		w.WriteString("CORS Headers Found:\n")
+		for _, header := range result.CORSHeaders {
+			w.WriteString(fmt.Sprintf("  - %s: %s\n", header, result.Headers[header]))
+		}
+	}
+
+	if len(result.Vulnerabilities) > 0 {
+		w.WriteString("Vulnerabilities:\n")
+		for _, vuln := range result.Vulnerabilities {
+			w.WriteString(fmt.Sprintf("  - [%s] %s origin=%s (%s)\n", vuln.Severity, vuln.Type, vuln.Origin, vuln.Evidence))
+		}
+	}
+
+	if len(result.Preflight) > 0 {
+		w.WriteString("Preflight:\n")
+		for _, preflight := range result.Preflight {
+			if preflight.Error != "" {
+				w.WriteString(fmt.Sprintf("  - %s: error: %s\n", preflight.RequestedMethod, preflight.Error))
+				continue
+			}
+			w.WriteString(fmt.Sprintf("  - %s: status=%d success=%t allowed_methods=%v allowed_headers=%v max_age=%s\n",
+				preflight.RequestedMethod, preflight.StatusCode, preflight.Success, preflight.AllowedMethods, preflight.AllowedHeaders, preflight.MaxAge))
+		}
+	}
+
+	w.WriteString("All Headers:\n")
+	for key, value := range result.Headers {
+		w.WriteString(fmt.Sprintf("  %s: %s\n", key, value))
+	}
+	w.WriteString("\n")
+
+	return nil
+}
+
+func (r *textReporter) Close() error {
+	if err := r.writer.Flush(); err != nil {
+		return err
+	}
+	return r.file.Close()
+}
+
+// jsonReporter buffers every result and writes a single JSON array on
+// Close, since a valid JSON array can't be streamed incrementally.
+type jsonReporter struct {
+	file    *os.File
+	results []CORSTestResult
+}
+
+func newJSONReporter(filename string) (*jsonReporter, error) {
+	file, err := os.Create(filename)
+	if err != nil {
+		return nil, err
+	}
+	return &jsonReporter{file: file}, nil
+}
+
+func (r *jsonReporter) Write(result CORSTestResult) error {
+	r.results = append(r.results, result)
+	return nil
+}
+
+func (r *jsonReporter) Close() error {
+	defer r.file.Close()
+	encoder := json.NewEncoder(r.file)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r.results)
+}
+
+// jsonlReporter writes one JSON object per line as each result arrives
+// and flushes immediately, so a scan that's interrupted midway still
+// leaves every completed result readable on disk.
+type jsonlReporter struct {
+	file   *os.File
+	writer *bufio.Writer
+}
+
+func newJSONLReporter(filename string) (*jsonlReporter, error) {
+	file, err := os.Create(filename)
+	if err != nil {
+		return nil, err
+	}
+	return &jsonlReporter{file: file, writer: bufio.NewWriter(file)}, nil
+}
+
+func (r *jsonlReporter) Write(result CORSTestResult) error {
+	encoded, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	if _, err := r.writer.Write(encoded); err != nil {
+		return err
+	}
+	if _, err := r.writer.WriteString("\n"); err != nil {
+		return err
+	}
+	return r.writer.Flush()
+}
+
+func (r *jsonlReporter) Close() error {
+	if err := r.writer.Flush(); err != nil {
+		return err
+	}
+	return r.file.Close()
+}
+
+// htmlReporter buffers every result and renders a single HTML report on
+// Close, grouping findings by severity with color-coded rows.
+type htmlReporter struct {
+	file    *os.File
+	results []CORSTestResult
+}
+
+func newHTMLReporter(filename string) (*htmlReporter, error) {
+	file, err := os.Create(filename)
+	if err != nil {
+		return nil, err
+	}
+	return &htmlReporter{file: file}, nil
+}
+
+func (r *htmlReporter) Write(result CORSTestResult) error {
+	r.results = append(r.results, result)
+	return nil
+}
+
+var severityColor = map[Severity]string{
+	SeverityCritical: "#d32f2f",
+	SeverityHigh:     "#f57c00",
+	SeverityMedium:   "#fbc02d",
+	SeverityLow:      "#388e3c",
+}
+
+func (r *htmlReporter) Close() error {
+	defer r.file.Close()
+
+	var b strings.Builder
+	b.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>CORS Scan Report</title>\n")
+	b.WriteString("<style>body{font-family:sans-serif;margin:2rem;}table{border-collapse:collapse;width:100%;}td,th{border:1px solid #ccc;padding:0.4rem 0.6rem;text-align:left;}tr.severity-row td:first-child{font-weight:bold;color:#fff;}</style>\n")
+	b.WriteString("</head>\n<body>\n<h1>CORS Scan Report</h1>\n<table>\n<tr><th>Severity</th><th>Type</th><th>URL</th><th>Origin</th><th>Evidence</th></tr>\n")
+
+	for _, severity := range []Severity{SeverityCritical, SeverityHigh, SeverityMedium, SeverityLow} {
+		for _, result := range r.results {
+			for _, vuln := range result.Vulnerabilities {
+				if vuln.Severity != severity {
+					continue
+				}
+				color := severityColor[vuln.Severity]
+				b.WriteString(fmt.Sprintf(
+					"<tr class=\"severity-row\"><td style=\"background:%s\">%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td></tr>\n",
+					color, html.EscapeString(string(vuln.Severity)), html.EscapeString(string(vuln.Type)),
+					html.EscapeString(result.URL), html.EscapeString(vuln.Origin), html.EscapeString(vuln.Evidence)))
+			}
+		}
+	}
+
+	b.WriteString("</table>\n</body>\n</html>\n")
+
+	_, err := r.file.WriteString(b.String())
+	return err
+}
+
+// markdownReporter buffers every result and renders a single Markdown
+// report on Close, with one table grouped by severity.
+type markdownReporter struct {
+	file    *os.File
+	results []CORSTestResult
+}
+
+func newMarkdownReporter(filename string) (*markdownReporter, error) {
+	file, err := os.Create(filename)
+	if err != nil {
+		return nil, err
+	}
+	return &markdownReporter{file: file}, nil
+}
+
+func (r *markdownReporter) Write(result CORSTestResult) error {
+	r.results = append(r.results, result)
+	return nil
+}
+
+func (r *markdownReporter) Close() error {
+	defer r.file.Close()
+
+	var b strings.Builder
+	b.WriteString("# CORS Scan Report\n\n")
+	b.WriteString("| Severity | Type | URL | Origin | Evidence |\n")
+	b.WriteString("|---|---|---|---|---|\n")
+
+	for _, severity := range []Severity{SeverityCritical, SeverityHigh, SeverityMedium, SeverityLow} {
+		for _, result := range r.results {
+			for _, vuln := range result.Vulnerabilities {
+				if vuln.Severity != severity {
+					continue
+				}
+				b.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s |\n",
+					vuln.Severity, vuln.Type, result.URL, vuln.Origin, vuln.Evidence))
+			}
+		}
+	}
+
+	_, err := r.file.WriteString(b.String())
+	return err
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func sampleResult() CORSTestResult {
+	return CORSTestResult{
+		URL:         "https://example.com",
+		StatusCode:  200,
+		Headers:     map[string]string{"Access-Control-Allow-Origin": "https://evil.example.com"},
+		HasCORS:     true,
+		CORSHeaders: []string{"Access-Control-Allow-Origin"},
+		Vulnerabilities: []CORSVulnerability{
+			{Type: VulnArbitraryOriginReflection, Severity: SeverityCritical, Origin: "https://evil.example.com", Evidence: "reflected"},
+		},
+		Timestamp: "2026-01-01T00:00:00Z",
+	}
+}
+
+func TestJSONReporterRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.json")
+
+	reporter, err := newJSONReporter(path)
+	if err != nil {
+		t.Fatalf("newJSONReporter: %v", err)
+	}
+
+	want := sampleResult()
+	if err := reporter.Write(want); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := reporter.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+
+	var got []CORSTestResult
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if len(got) != 1 || got[0].URL != want.URL {
+		t.Fatalf("got %+v, want one result for %s", got, want.URL)
+	}
+}
+
+func TestJSONLReporterRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.jsonl")
+
+	reporter, err := newJSONLReporter(path)
+	if err != nil {
+		t.Fatalf("newJSONLReporter: %v", err)
+	}
+
+	a := sampleResult()
+	b := sampleResult()
+	b.URL = "https://second.example.com"
+
+	if err := reporter.Write(a); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := reporter.Write(b); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := reporter.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 JSONL lines, got %d", len(lines))
+	}
+	for i, line := range lines {
+		var result CORSTestResult
+		if err := json.Unmarshal([]byte(line), &result); err != nil {
+			t.Fatalf("line %d: Unmarshal: %v", i, err)
+		}
+	}
+}
+
+func TestNewReporterUnknownFormat(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.txt")
+	if _, err := newReporter([]string{"yaml"}, path); err == nil {
+		t.Fatal("expected an error for an unknown report format")
+	}
+}
+
+func TestHTMLAndMarkdownReportersWriteVulnerabilities(t *testing.T) {
+	dir := t.TempDir()
+	result := sampleResult()
+
+	htmlPath := filepath.Join(dir, "out.html")
+	htmlReporter, err := newHTMLReporter(htmlPath)
+	if err != nil {
+		t.Fatalf("newHTMLReporter: %v", err)
+	}
+	if err := htmlReporter.Write(result); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := htmlReporter.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	htmlData, err := os.ReadFile(htmlPath)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if !strings.Contains(string(htmlData), string(VulnArbitraryOriginReflection)) {
+		t.Fatalf("expected HTML report to mention the vulnerability type, got:\n%s", htmlData)
+	}
+
+	mdPath := filepath.Join(dir, "out.md")
+	mdReporter, err := newMarkdownReporter(mdPath)
+	if err != nil {
+		t.Fatalf("newMarkdownReporter: %v", err)
+	}
+	if err := mdReporter.Write(result); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := mdReporter.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	mdData, err := os.ReadFile(mdPath)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if !strings.Contains(string(mdData), result.URL) {
+		t.Fatalf("expected Markdown report to mention the URL, got:\n%s", mdData)
+	}
+}
+
+func TestReportFilename(t *testing.T) {
+	tests := []struct {
+		name       string
+		outputFile string
+		format     string
+		want       string
+	}{
+		{"text keeps the original filename", "results.txt", "text", "results.txt"},
+		{"json swaps the extension", "results.txt", "json", "results.json"},
+		{"jsonl swaps the extension", "results.txt", "jsonl", "results.jsonl"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := reportFilename(tt.outputFile, tt.format); got != tt.want {
+				t.Fatalf("reportFilename(%q, %q) = %q, want %q", tt.outputFile, tt.format, got, tt.want)
+			}
+		})
+	}
+}
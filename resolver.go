@@ -0,0 +1,109 @@
+package main
+
+import (
+	"bufio"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// loadWordlist reads one entry per line from path, trimming whitespace and
+// skipping blank lines and "#"-prefixed comments.
+func loadWordlist(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var entries []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		entries = append(entries, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// expandTargets synthesizes the cartesian product of subdomains and paths
+// against each URL in urls, e.g. for host "foo.com" and subdomain "dev"
+// and path "/api", it produces "https://dev.foo.com/api". When both
+// subdomains and paths are empty, urls is returned unchanged.
+func expandTargets(urls []string, subdomains []string, paths []string) []string {
+	if len(subdomains) == 0 && len(paths) == 0 {
+		return urls
+	}
+
+	subs := subdomains
+	if len(subs) == 0 {
+		subs = []string{""}
+	}
+	pths := paths
+	if len(pths) == 0 {
+		pths = []string{""}
+	}
+
+	var expanded []string
+	for _, raw := range urls {
+		parsed, err := url.Parse(raw)
+		if err != nil {
+			expanded = append(expanded, raw)
+			continue
+		}
+
+		for _, sub := range subs {
+			host := parsed.Host
+			if sub != "" {
+				host = sub + "." + host
+			}
+			for _, path := range pths {
+				target := *parsed
+				target.Host = host
+				if path != "" {
+					target.Path = path
+				}
+				expanded = append(expanded, target.String())
+			}
+		}
+	}
+	return expanded
+}
+
+// parseStatusSet parses a comma-separated list of status codes (e.g.
+// "404,403") into a lookup set. Invalid entries are silently skipped.
+func parseStatusSet(raw string) map[int]bool {
+	if raw == "" {
+		return nil
+	}
+
+	set := make(map[int]bool)
+	for _, part := range splitAndTrim(raw) {
+		code, err := strconv.Atoi(part)
+		if err != nil {
+			continue
+		}
+		set[code] = true
+	}
+	return set
+}
+
+// statusAllowed reports whether a result with statusCode should be kept,
+// given the configured whitelist/blacklist. The whitelist takes
+// precedence: when set, only listed codes survive; otherwise, blacklisted
+// codes are dropped.
+func statusAllowed(statusCode int, blacklist, whitelist map[int]bool) bool {
+	if len(whitelist) > 0 {
+		return whitelist[statusCode]
+	}
+	if len(blacklist) > 0 {
+		return !blacklist[statusCode]
+	}
+	return true
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStatusAllowed(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		blacklist  map[int]bool
+		whitelist  map[int]bool
+		want       bool
+	}{
+		{"no filters allows everything", 200, nil, nil, true},
+		{"blacklisted status dropped", 404, map[int]bool{404: true}, nil, false},
+		{"non-blacklisted status kept", 200, map[int]bool{404: true}, nil, true},
+		{"whitelist keeps a listed status", 200, nil, map[int]bool{200: true}, true},
+		{"whitelist takes precedence over blacklist", 200, map[int]bool{200: true}, map[int]bool{200: true}, true},
+		{"whitelist drops anything not listed", 403, map[int]bool{403: true}, map[int]bool{200: true}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := statusAllowed(tt.statusCode, tt.blacklist, tt.whitelist); got != tt.want {
+				t.Fatalf("statusAllowed(%d) = %v, want %v", tt.statusCode, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseStatusSet(t *testing.T) {
+	got := parseStatusSet("404,403, 401")
+	want := map[int]bool{404: true, 403: true, 401: true}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("parseStatusSet() = %v, want %v", got, want)
+	}
+
+	if got := parseStatusSet(""); got != nil {
+		t.Fatalf("parseStatusSet(\"\") = %v, want nil", got)
+	}
+}
+
+func TestExpandTargetsNoWordlists(t *testing.T) {
+	urls := []string{"https://foo.com/path"}
+	got := expandTargets(urls, nil, nil)
+	if !reflect.DeepEqual(got, urls) {
+		t.Fatalf("expandTargets() = %v, want unchanged %v", got, urls)
+	}
+}
+
+func TestExpandTargetsSubdomainsAndPaths(t *testing.T) {
+	urls := []string{"https://foo.com"}
+	got := expandTargets(urls, []string{"dev", "staging"}, []string{"/api", "/admin"})
+
+	want := []string{
+		"https://dev.foo.com/api",
+		"https://dev.foo.com/admin",
+		"https://staging.foo.com/api",
+		"https://staging.foo.com/admin",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expandTargets() = %v, want %v", got, want)
+	}
+}
+
+func TestExpandTargetsPreservesOriginalPathWhenNoPathWordlist(t *testing.T) {
+	urls := []string{"https://foo.com/keep-me"}
+	got := expandTargets(urls, []string{"dev"}, nil)
+
+	want := []string{"https://dev.foo.com/keep-me"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expandTargets() = %v, want %v", got, want)
+	}
+}
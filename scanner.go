@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+	"time"
+)
+
+// Scanner holds the scan configuration and worker pool shared by the CLI
+// and the HTTP daemon, so both entry points drive the exact same core
+// scanning logic.
+type Scanner struct {
+	Config Config
+	Pool   *WorkerPool
+}
+
+// NewScanner builds a Scanner with a worker pool sized from
+// config.Concurrency.
+func NewScanner(config Config) *Scanner {
+	return &Scanner{
+		Config: config,
+		Pool:   NewWorkerPool(config.Concurrency),
+	}
+}
+
+// Scan expands targets per the scanner's wordlist config, runs them
+// through testCORS concurrently, records metrics, and streams each
+// status-filtered result to onResult as it completes.
+func (s *Scanner) Scan(ctx context.Context, targets []string, onResult func(CORSTestResult)) []CORSTestResult {
+	targets = expandTargets(targets, s.Config.Subdomains, s.Config.Paths)
+
+	return s.Pool.Run(ctx, targets, func(target string) CORSTestResult {
+		start := time.Now()
+		result := testCORS(target, s.Config)
+		recordMetrics(result, time.Since(start))
+		return result
+	}, func(result CORSTestResult) {
+		if !statusAllowed(result.StatusCode, s.Config.StatusBlacklist, s.Config.StatusWhitelist) {
+			return
+		}
+		if onResult != nil {
+			onResult(result)
+		}
+	})
+}
@@ -0,0 +1,314 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/rs/zerolog"
+)
+
+// ScanRequest is the JSON body accepted by POST /scans.
+type ScanRequest struct {
+	URLs        []string `json:"urls"`
+	Method      string   `json:"method"`
+	Concurrency int      `json:"concurrency"`
+}
+
+// ScanJob tracks one scan submitted through the HTTP API: its progress,
+// the results gathered so far, and any SSE subscribers waiting on new
+// ones.
+type ScanJob struct {
+	ID     string
+	Total  int
+	cancel context.CancelFunc
+
+	mu      sync.Mutex
+	status  string // "running", "completed", "cancelled"
+	results []CORSTestResult
+	subs    map[chan CORSTestResult]struct{}
+}
+
+// ScanJobView is the JSON-serializable snapshot returned by GET /scans/{id}.
+type ScanJobView struct {
+	ID        string           `json:"id"`
+	Status    string           `json:"status"`
+	Total     int              `json:"total"`
+	Completed int              `json:"completed"`
+	Results   []CORSTestResult `json:"results"`
+}
+
+func (j *ScanJob) appendResult(result CORSTestResult) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.results = append(j.results, result)
+	for ch := range j.subs {
+		select {
+		case ch <- result:
+		default:
+		}
+	}
+}
+
+func (j *ScanJob) subscribe() chan CORSTestResult {
+	ch := make(chan CORSTestResult, 16)
+	j.mu.Lock()
+	j.subs[ch] = struct{}{}
+	j.mu.Unlock()
+	return ch
+}
+
+// subscribeWithBacklog registers ch for future results and, atomically
+// with that registration, returns every result gathered so far plus the
+// job's current status — so a caller replaying the backlog can't miss or
+// duplicate a result that arrives in between.
+func (j *ScanJob) subscribeWithBacklog() (ch chan CORSTestResult, backlog []CORSTestResult, status string) {
+	ch = make(chan CORSTestResult, 16)
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.subs[ch] = struct{}{}
+	backlog = append([]CORSTestResult(nil), j.results...)
+	status = j.status
+	return ch, backlog, status
+}
+
+func (j *ScanJob) unsubscribe(ch chan CORSTestResult) {
+	j.mu.Lock()
+	if _, ok := j.subs[ch]; ok {
+		delete(j.subs, ch)
+		close(ch)
+	}
+	j.mu.Unlock()
+}
+
+func (j *ScanJob) markDone() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.status == "running" {
+		j.status = "completed"
+	}
+	for ch := range j.subs {
+		close(ch)
+	}
+	j.subs = make(map[chan CORSTestResult]struct{})
+}
+
+func (j *ScanJob) markCancelled() {
+	j.cancel()
+	j.mu.Lock()
+	j.status = "cancelled"
+	j.mu.Unlock()
+}
+
+func (j *ScanJob) view() ScanJobView {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return ScanJobView{
+		ID:        j.ID,
+		Status:    j.status,
+		Total:     j.Total,
+		Completed: len(j.results),
+		Results:   append([]CORSTestResult(nil), j.results...),
+	}
+}
+
+// JobServer exposes the CORS scanner as an HTTP daemon: POST /scans
+// starts a scan and returns its ID, GET /scans/{id} reports progress,
+// GET /scans/{id}/stream pushes results over SSE, and DELETE /scans/{id}
+// cancels an in-flight scan. The CLI and the daemon both drive scans
+// through the same Scanner type.
+type JobServer struct {
+	base   Config
+	logger zerolog.Logger
+
+	mu   sync.Mutex
+	jobs map[string]*ScanJob
+}
+
+func NewJobServer(base Config, logger zerolog.Logger) *JobServer {
+	return &JobServer{base: base, logger: logger, jobs: make(map[string]*ScanJob)}
+}
+
+// Handler builds the daemon's route table. Pass enableMetrics to also
+// expose a Prometheus /metrics endpoint for continuous-monitoring
+// deployments.
+func (s *JobServer) Handler(enableMetrics bool) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /scans", s.handleCreate)
+	mux.HandleFunc("GET /scans/{id}", s.handleGet)
+	mux.HandleFunc("GET /scans/{id}/stream", s.handleStream)
+	mux.HandleFunc("DELETE /scans/{id}", s.handleDelete)
+	if enableMetrics {
+		mux.Handle("GET /metrics", promhttp.Handler())
+	}
+	return mux
+}
+
+func (s *JobServer) handleCreate(w http.ResponseWriter, r *http.Request) {
+	var req ScanRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("invalid request body: %v", err), http.StatusBadRequest)
+		return
+	}
+	if len(req.URLs) == 0 {
+		http.Error(w, "urls must not be empty", http.StatusBadRequest)
+		return
+	}
+
+	config := s.base
+	if req.Method != "" {
+		config.Method = req.Method
+	}
+	if req.Concurrency > 0 {
+		config.Concurrency = req.Concurrency
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	job := &ScanJob{
+		ID:     newScanID(),
+		Total:  len(req.URLs),
+		status: "running",
+		cancel: cancel,
+		subs:   make(map[chan CORSTestResult]struct{}),
+	}
+
+	s.mu.Lock()
+	s.jobs[job.ID] = job
+	s.mu.Unlock()
+
+	scanner := NewScanner(config)
+	go func() {
+		scanner.Scan(ctx, req.URLs, job.appendResult)
+		job.markDone()
+	}()
+
+	s.logger.Info().Str("scan_id", job.ID).Int("targets", job.Total).Msg("scan started")
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"id": job.ID})
+}
+
+func (s *JobServer) lookup(r *http.Request) *ScanJob {
+	id := r.PathValue("id")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.jobs[id]
+}
+
+func (s *JobServer) handleGet(w http.ResponseWriter, r *http.Request) {
+	job := s.lookup(r)
+	if job == nil {
+		http.Error(w, "scan not found", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(job.view())
+}
+
+func (s *JobServer) handleStream(w http.ResponseWriter, r *http.Request) {
+	job := s.lookup(r)
+	if job == nil {
+		http.Error(w, "scan not found", http.StatusNotFound)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	ch, backlog, status := job.subscribeWithBacklog()
+	defer job.unsubscribe(ch)
+
+	for _, result := range backlog {
+		encoded, err := json.Marshal(result)
+		if err != nil {
+			continue
+		}
+		fmt.Fprintf(w, "data: %s\n\n", encoded)
+	}
+	flusher.Flush()
+
+	if status != "running" {
+		return
+	}
+
+	for {
+		select {
+		case result, ok := <-ch:
+			if !ok {
+				return
+			}
+			encoded, err := json.Marshal(result)
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(w, "data: %s\n\n", encoded)
+			flusher.Flush()
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+func (s *JobServer) handleDelete(w http.ResponseWriter, r *http.Request) {
+	job := s.lookup(r)
+	if job == nil {
+		http.Error(w, "scan not found", http.StatusNotFound)
+		return
+	}
+	job.markCancelled()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// newScanID returns a short random hex identifier for a new scan job.
+func newScanID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("scan-%d", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
+
+// runServe implements the `cors-tool serve` subcommand: a long-running
+// HTTP daemon that accepts scan jobs instead of exiting after one batch.
+func runServe(args []string) {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	listen := fs.String("listen", ":8080", "Address to listen on")
+	metrics := fs.Bool("metrics", false, "Expose a Prometheus /metrics endpoint")
+	method := fs.String("method", "GET", "Default HTTP method for scans that don't override it")
+	concurrency := fs.Int("concurrency", 5, "Default concurrency for scans that don't override it")
+	timeout := fs.Int("timeout", 10, "Default request timeout in seconds")
+	logFormat := fs.String("log-format", "console", "Log output format: console or json")
+	fs.Parse(args)
+
+	logger := newLogger(false, *logFormat)
+
+	base := Config{
+		Method:      *method,
+		Timeout:     time.Duration(*timeout) * time.Second,
+		Concurrency: *concurrency,
+		Headers: map[string]string{
+			"User-Agent": "CORS-Testing-Tool/1.0",
+		},
+	}
+
+	server := NewJobServer(base, logger)
+
+	logger.Info().Str("listen", *listen).Bool("metrics", *metrics).Msg("starting CORS scan daemon")
+	if err := http.ListenAndServe(*listen, server.Handler(*metrics)); err != nil {
+		logger.Fatal().Err(err).Msg("server exited")
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestReflectsOriginWithCredentials(t *testing.T) {
+	tests := []struct {
+		name   string
+		acao   string
+		acac   string
+		origin string
+		want   bool
+	}{
+		{"reflects with credentials", "https://evil.example.com", "true", "https://evil.example.com", true},
+		{"reflects without credentials", "https://evil.example.com", "", "https://evil.example.com", true},
+		{"does not reflect a different origin", "https://trusted.example.com", "true", "https://evil.example.com", false},
+		{"wildcard is not a reflection", "*", "true", "https://evil.example.com", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{Header: http.Header{}}
+			if tt.acao != "" {
+				resp.Header.Set("Access-Control-Allow-Origin", tt.acao)
+			}
+			if tt.acac != "" {
+				resp.Header.Set("Access-Control-Allow-Credentials", tt.acac)
+			}
+
+			got, evidence := reflectsOriginWithCredentials(resp, tt.origin)
+			if got != tt.want {
+				t.Fatalf("reflectsOriginWithCredentials() = %v, want %v", got, tt.want)
+			}
+			if got && evidence == "" {
+				t.Fatalf("expected non-empty evidence when reflected")
+			}
+		})
+	}
+}
+
+func TestDetectVulnerabilitiesFlagsOriginReflection(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Access-Control-Allow-Origin", r.Header.Get("Origin"))
+		w.Header().Set("Access-Control-Allow-Credentials", "true")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	config := Config{Method: http.MethodGet, Timeout: 5 * time.Second}
+	client := &http.Client{Timeout: config.Timeout}
+
+	vulns := detectVulnerabilities(server.URL, config, client)
+
+	found := false
+	for _, v := range vulns {
+		if v.Type == VulnArbitraryOriginReflection && v.Severity == SeverityCritical {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected a critical arbitrary-origin-reflection vulnerability, got %+v", vulns)
+	}
+}
+
+func TestDetectVulnerabilitiesIgnoresFixedAllowOrigin(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Access-Control-Allow-Origin", "https://trusted.example.com")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	config := Config{Method: http.MethodGet, Timeout: 5 * time.Second}
+	client := &http.Client{Timeout: config.Timeout}
+
+	vulns := detectVulnerabilities(server.URL, config, client)
+	if len(vulns) != 0 {
+		t.Fatalf("expected no vulnerabilities for a server with a fixed allow-origin, got %+v", vulns)
+	}
+}
+
+func TestDetectWildcardWithCredentials(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    bool
+	}{
+		{"flags wildcard with credentials", map[string]string{"Access-Control-Allow-Origin": "*", "Access-Control-Allow-Credentials": "true"}, true},
+		{"wildcard without credentials is fine", map[string]string{"Access-Control-Allow-Origin": "*"}, false},
+		{"credentials without wildcard is fine", map[string]string{"Access-Control-Allow-Origin": "https://trusted.example.com", "Access-Control-Allow-Credentials": "true"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := detectWildcardWithCredentials(tt.headers)
+			if (got != nil) != tt.want {
+				t.Fatalf("detectWildcardWithCredentials() = %v, want non-nil=%v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"sync"
+)
+
+// WorkerPool runs a bounded number of concurrent jobs against a list of
+// targets. It is the reusable form of the semaphore/goroutine logic that
+// used to live inline in testBulkCORS, so the CLI and the HTTP daemon
+// share one implementation.
+type WorkerPool struct {
+	concurrency int
+}
+
+// NewWorkerPool returns a WorkerPool that runs at most concurrency jobs
+// at once (at least 1).
+func NewWorkerPool(concurrency int) *WorkerPool {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	return &WorkerPool{concurrency: concurrency}
+}
+
+// Run calls work once per target, at most p.concurrency at a time, and
+// invokes onResult (if non-nil) for each completed result as soon as
+// it's ready. Cancelling ctx stops new work from starting; in-flight
+// jobs still finish and report their result. Run returns every result
+// that completed before ctx was cancelled.
+func (p *WorkerPool) Run(ctx context.Context, targets []string, work func(string) CORSTestResult, onResult func(CORSTestResult)) []CORSTestResult {
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, p.concurrency)
+	resultsCh := make(chan CORSTestResult)
+
+	for _, target := range targets {
+		wg.Add(1)
+		go func(t string) {
+			defer wg.Done()
+
+			select {
+			case semaphore <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			defer func() { <-semaphore }()
+
+			if ctx.Err() != nil {
+				return
+			}
+
+			resultsCh <- work(t)
+		}(target)
+	}
+
+	go func() {
+		wg.Wait()
+		close(resultsCh)
+	}()
+
+	var results []CORSTestResult
+	for result := range resultsCh {
+		results = append(results, result)
+		if onResult != nil {
+			onResult(result)
+		}
+	}
+	return results
+}